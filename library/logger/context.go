@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type contextKey uint64
+
+const (
+	contextFields contextKey = iota
+)
+
+// WithFields returns a context carrying fields on top of any already attached
+// to ctx via a previous WithFields call; a repeated key is overwritten rather
+// than duplicated.
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	return context.WithValue(ctx, contextFields, mergeFields(Fields(ctx), fields))
+}
+
+// Fields extracts the fields previously attached to ctx via WithFields.
+func Fields(ctx context.Context) []zap.Field {
+	fields, ok := ctx.Value(contextFields).([]zap.Field)
+	if !ok {
+		return nil
+	}
+	return fields
+}
+
+// mergeFields appends extra onto base, key-indexed so a field in extra
+// overwrites one of the same key in base instead of duplicating it.
+func mergeFields(base, extra []zap.Field) []zap.Field {
+	merged := make([]zap.Field, 0, len(base)+len(extra))
+	index := make(map[string]int, len(base)+len(extra))
+
+	add := func(f zap.Field) {
+		if i, ok := index[f.Key]; ok {
+			merged[i] = f
+			return
+		}
+		index[f.Key] = len(merged)
+		merged = append(merged, f)
+	}
+
+	for _, f := range base {
+		add(f)
+	}
+	for _, f := range extra {
+		add(f)
+	}
+
+	return merged
+}
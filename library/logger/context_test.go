@@ -0,0 +1,37 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestWithFields(t *testing.T) {
+	convey.Convey("TestWithFields", t, func() {
+		convey.Convey("accumulates across calls", func() {
+			ctx := context.Background()
+			ctx = WithFields(ctx, zap.String("a", "1"))
+			ctx = WithFields(ctx, zap.String("b", "2"))
+
+			fields := Fields(ctx)
+			assert.Len(t, fields, 2)
+		})
+
+		convey.Convey("a later field overwrites one of the same key", func() {
+			ctx := context.Background()
+			ctx = WithFields(ctx, zap.String("a", "1"))
+			ctx = WithFields(ctx, zap.String("a", "2"))
+
+			fields := Fields(ctx)
+			assert.Len(t, fields, 1)
+			assert.Equal(t, fields[0].String, "2")
+		})
+
+		convey.Convey("empty context has no fields", func() {
+			assert.Len(t, Fields(context.Background()), 0)
+		})
+	})
+}
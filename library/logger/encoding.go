@@ -0,0 +1,141 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/term"
+)
+
+// logfmtEncoder renders each entry as "key=value" pairs, one log line per entry.
+type logfmtEncoder struct {
+	*zapcore.MapObjectEncoder
+	cfg  zapcore.EncoderConfig
+	pool buffer.Pool
+}
+
+func newLogfmtEncoder(cfg zapcore.EncoderConfig) zapcore.Encoder {
+	return &logfmtEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		cfg:              cfg,
+		pool:             buffer.NewPool(),
+	}
+}
+
+// AddDuration and AddTime override the embedded MapObjectEncoder's, which
+// otherwise store the raw Go value and never apply cfg.EncodeDuration/
+// EncodeTime the way the entry-level time key does, so WithDurationUnit and
+// WithTimeLayout would silently be ignored for ordinary fields.
+func (e *logfmtEncoder) AddDuration(key string, val time.Duration) {
+	if e.cfg.EncodeDuration == nil {
+		e.MapObjectEncoder.AddDuration(key, val)
+		return
+	}
+	var capture primitiveCapture
+	e.cfg.EncodeDuration(val, &capture)
+	e.Fields[key] = capture.value
+}
+
+func (e *logfmtEncoder) AddTime(key string, val time.Time) {
+	if e.cfg.EncodeTime == nil {
+		e.MapObjectEncoder.AddTime(key, val)
+		return
+	}
+	var capture primitiveCapture
+	e.cfg.EncodeTime(val, &capture)
+	e.Fields[key] = capture.value
+}
+
+func (e *logfmtEncoder) Clone() zapcore.Encoder {
+	clone := &logfmtEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		cfg:              e.cfg,
+		pool:             e.pool,
+	}
+	for k, v := range e.Fields {
+		clone.Fields[k] = v
+	}
+	return clone
+}
+
+func (e *logfmtEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	enc := e.Clone().(*logfmtEncoder)
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	line := e.pool.Get()
+
+	if e.cfg.TimeKey != "" && e.cfg.EncodeTime != nil {
+		var capture primitiveCapture
+		e.cfg.EncodeTime(ent.Time, &capture)
+		writeLogfmtPair(line, e.cfg.TimeKey, capture.value)
+	}
+	if e.cfg.LevelKey != "" {
+		writeLogfmtPair(line, e.cfg.LevelKey, ent.Level.String())
+	}
+	if e.cfg.NameKey != "" && ent.LoggerName != "" {
+		writeLogfmtPair(line, e.cfg.NameKey, ent.LoggerName)
+	}
+	if e.cfg.CallerKey != "" && ent.Caller.Defined {
+		writeLogfmtPair(line, e.cfg.CallerKey, ent.Caller.TrimmedPath())
+	}
+	if e.cfg.MessageKey != "" {
+		writeLogfmtPair(line, e.cfg.MessageKey, ent.Message)
+	}
+	for k, v := range enc.Fields {
+		writeLogfmtPair(line, k, v)
+	}
+	if ent.Stack != "" && e.cfg.StacktraceKey != "" {
+		writeLogfmtPair(line, e.cfg.StacktraceKey, ent.Stack)
+	}
+
+	line.AppendString("\n")
+
+	return line, nil
+}
+
+// primitiveCapture is a throwaway zapcore.PrimitiveArrayEncoder that records the
+// single value an EncoderConfig.EncodeTime/EncodeDuration callback appends to it.
+type primitiveCapture struct{ value interface{} }
+
+func (c *primitiveCapture) AppendBool(v bool)             { c.value = v }
+func (c *primitiveCapture) AppendByteString(v []byte)     { c.value = string(v) }
+func (c *primitiveCapture) AppendComplex128(v complex128) { c.value = v }
+func (c *primitiveCapture) AppendComplex64(v complex64)   { c.value = v }
+func (c *primitiveCapture) AppendFloat64(v float64)       { c.value = v }
+func (c *primitiveCapture) AppendFloat32(v float32)       { c.value = v }
+func (c *primitiveCapture) AppendInt(v int)               { c.value = v }
+func (c *primitiveCapture) AppendInt64(v int64)           { c.value = v }
+func (c *primitiveCapture) AppendInt32(v int32)           { c.value = v }
+func (c *primitiveCapture) AppendInt16(v int16)           { c.value = v }
+func (c *primitiveCapture) AppendInt8(v int8)             { c.value = v }
+func (c *primitiveCapture) AppendString(v string)         { c.value = v }
+func (c *primitiveCapture) AppendUint(v uint)             { c.value = v }
+func (c *primitiveCapture) AppendUint64(v uint64)         { c.value = v }
+func (c *primitiveCapture) AppendUint32(v uint32)         { c.value = v }
+func (c *primitiveCapture) AppendUint16(v uint16)         { c.value = v }
+func (c *primitiveCapture) AppendUint8(v uint8)           { c.value = v }
+func (c *primitiveCapture) AppendUintptr(v uintptr)       { c.value = v }
+
+func writeLogfmtPair(line *buffer.Buffer, key string, val interface{}) {
+	if line.Len() > 0 {
+		line.AppendByte(' ')
+	}
+	line.AppendString(key)
+	line.AppendByte('=')
+
+	str := fmt.Sprint(val)
+	if strings.ContainsAny(str, " =\"") {
+		str = fmt.Sprintf("%q", str)
+	}
+	line.AppendString(str)
+}
+
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
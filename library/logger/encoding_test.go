@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestLogger_WithEncoding(t *testing.T) {
+	convey.Convey("TestLogger_WithEncoding", t, func() {
+		convey.Convey("json is the default", func() {
+			var buf bytes.Buffer
+			l, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(&buf), WithErrorWriter(&buf))
+			assert.Equal(t, err, nil)
+
+			l.Info(context.Background(), "hello")
+			assert.Contains(t, buf.String(), `"msg":"hello"`)
+		})
+
+		convey.Convey("console renders the message without a JSON msg key", func() {
+			var buf bytes.Buffer
+			l, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(&buf), WithErrorWriter(&buf), WithEncoding("console"))
+			assert.Equal(t, err, nil)
+
+			l.Info(context.Background(), "hello")
+			assert.Contains(t, buf.String(), "hello")
+			assert.NotContains(t, buf.String(), `"msg"`)
+		})
+
+		convey.Convey("logfmt renders key=value pairs", func() {
+			var buf bytes.Buffer
+			l, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(&buf), WithErrorWriter(&buf), WithEncoding("logfmt"))
+			assert.Equal(t, err, nil)
+
+			l.Info(context.Background(), "hello")
+			assert.Contains(t, buf.String(), "msg=hello")
+			assert.Contains(t, buf.String(), "module=default")
+		})
+
+		convey.Convey("Config.Encoding picks the format when WithEncoding isn't given", func() {
+			var buf bytes.Buffer
+			l, err := NewLogger(&Config{Level: "info", Encoding: "console"}, WithInfoWriter(&buf), WithErrorWriter(&buf))
+			assert.Equal(t, err, nil)
+
+			l.Info(context.Background(), "hello")
+			assert.Contains(t, buf.String(), "hello")
+			assert.NotContains(t, buf.String(), `"msg"`)
+		})
+
+		convey.Convey("WithEncoding overrides Config.Encoding", func() {
+			var buf bytes.Buffer
+			l, err := NewLogger(&Config{Level: "info", Encoding: "console"}, WithInfoWriter(&buf), WithErrorWriter(&buf), WithEncoding("json"))
+			assert.Equal(t, err, nil)
+
+			l.Info(context.Background(), "hello")
+			assert.Contains(t, buf.String(), `"msg":"hello"`)
+		})
+	})
+}
+
+func TestLogger_WithColor(t *testing.T) {
+	convey.Convey("TestLogger_WithColor", t, func() {
+		convey.Convey("has no effect when stdout isn't a terminal", func() {
+			var buf bytes.Buffer
+			l, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(&buf), WithErrorWriter(&buf),
+				WithEncoding("console"), WithColor(true))
+			assert.Equal(t, err, nil)
+
+			l.Info(context.Background(), "hello")
+			assert.NotContains(t, buf.String(), "\x1b[")
+		})
+	})
+}
+
+func TestLogger_WithDurationUnit(t *testing.T) {
+	convey.Convey("TestLogger_WithDurationUnit", t, func() {
+		convey.Convey("truncates a zap.Duration field to the configured unit", func() {
+			var buf bytes.Buffer
+			l, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(&buf), WithErrorWriter(&buf),
+				WithDurationUnit(time.Second))
+			assert.Equal(t, err, nil)
+
+			l.Logger.Info("hello", zap.Duration("elapsed", 2500*time.Millisecond))
+			assert.Contains(t, buf.String(), `"elapsed":2`)
+		})
+
+		convey.Convey("also truncates a zap.Duration field under logfmt", func() {
+			var buf bytes.Buffer
+			l, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(&buf), WithErrorWriter(&buf),
+				WithEncoding("logfmt"), WithDurationUnit(time.Second))
+			assert.Equal(t, err, nil)
+
+			l.Logger.Info("hello", zap.Duration("elapsed", 2500*time.Millisecond))
+			assert.Contains(t, buf.String(), "elapsed=2")
+			assert.NotContains(t, buf.String(), "elapsed=2.5s")
+		})
+	})
+}
+
+func TestLogger_WithTimeLayout(t *testing.T) {
+	convey.Convey("TestLogger_WithTimeLayout", t, func() {
+		convey.Convey("applies the configured layout to a zap.Time field under logfmt", func() {
+			var buf bytes.Buffer
+			l, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(&buf), WithErrorWriter(&buf),
+				WithEncoding("logfmt"), WithTimeLayout("2006-01-02"))
+			assert.Equal(t, err, nil)
+
+			l.Logger.Info("hello", zap.Time("at", time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)))
+			assert.Contains(t, buf.String(), "at=2024-03-05")
+		})
+	})
+}
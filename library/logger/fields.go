@@ -0,0 +1,9 @@
+package logger
+
+const (
+	Module     = "module"
+	SericeName = "service_name"
+	RequestID  = "request_id"
+	TraceID    = "trace_id"
+	SpanID     = "span_id"
+)
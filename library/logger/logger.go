@@ -2,12 +2,14 @@ package logger
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
+	"net/http"
 	"os"
 	"time"
 
-	"github.com/why444216978/go-util/conversion"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -15,24 +17,75 @@ import (
 // Config is used to parse configuration file
 // logger should be controlled with Options
 type Config struct {
-	InfoFile  string
-	ErrorFile string
-	Level     string
+	InfoFile   string
+	ErrorFile  string
+	Level      string
+	Encoding   string
+	MaxSizeMB  int
+	MaxAgeDays int
+	MaxBackups int
+	Compress   bool
 }
 
 type Logger struct {
 	*zap.Logger
-	opts  *Options
-	level zapcore.Level
+	opts           *Options
+	level          zap.AtomicLevel
+	rateLimitStats *RateLimitStats
+
+	// encoder, infoWriter and errorWriter are the resolved building blocks
+	// behind this Logger's cores, kept around so LoggerRegistry can derive a
+	// named child logger that writes through the same encoder/writers but is
+	// gated solely by its own AtomicLevel instead of this Logger's.
+	encoder     zapcore.Encoder
+	infoWriter  io.Writer
+	errorWriter io.Writer
 }
 
 type Options struct {
-	level       string
-	callSkip    int
-	module      string
-	serviceName string
-	infoWriter  io.Writer
-	errorWriter io.Writer
+	level          string
+	callSkip       int
+	module         string
+	serviceName    string
+	infoWriter     io.Writer
+	errorWriter    io.Writer
+	infoWriterSet  bool
+	errorWriterSet bool
+	rotation       RotationPolicy
+	rotationSet    bool
+	sinks          []sinkOption
+	encoding       string
+	encodingSet    bool
+	timeLayout     string
+	color          bool
+	durationUnit   time.Duration
+
+	samplingSet        bool
+	samplingInitial    int
+	samplingThereafter int
+	samplingTick       time.Duration
+
+	rateLimitSet       bool
+	rateLimitPerSecond int
+	rateLimitBurst     int
+}
+
+// sinkOption describes an extra zapcore.Core, covering [minLevel, maxLevel],
+// added via WithSink alongside the built-in info/error cores.
+type sinkOption struct {
+	name     string
+	minLevel zapcore.Level
+	maxLevel zapcore.Level
+	writer   io.Writer
+}
+
+func (s sinkOption) levelEnabler(level zap.AtomicLevel) zap.LevelEnablerFunc {
+	return zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+		if !level.Enabled(lvl) {
+			return false
+		}
+		return lvl >= s.minLevel && lvl <= s.maxLevel
+	})
 }
 
 type Option func(l *Options)
@@ -45,6 +98,8 @@ func defaultOptions() *Options {
 		serviceName: "default",
 		infoWriter:  os.Stdout,
 		errorWriter: os.Stdout,
+		rotation:    defaultRotationPolicy(),
+		encoding:    "json",
 	}
 }
 
@@ -61,17 +116,90 @@ func WithServiceName(serviceName string) Option {
 }
 
 func WithInfoWriter(w io.Writer) Option {
-	return func(o *Options) { o.infoWriter = w }
+	return func(o *Options) {
+		o.infoWriter = w
+		o.infoWriterSet = true
+	}
 }
 
 func WithErrorWriter(w io.Writer) Option {
-	return func(o *Options) { o.errorWriter = w }
+	return func(o *Options) {
+		o.errorWriter = w
+		o.errorWriterSet = true
+	}
 }
 
 func WithLevel(l string) Option {
 	return func(o *Options) { o.level = l }
 }
 
+// WithRotation overrides the file rotation policy derived from Config's
+// MaxSizeMB/MaxAgeDays/MaxBackups/Compress fields.
+func WithRotation(policy RotationPolicy) Option {
+	return func(o *Options) {
+		o.rotation = policy
+		o.rotationSet = true
+	}
+}
+
+// WithSink adds an extra zapcore.Core, covering [minLevel, maxLevel], that writes
+// alongside the built-in info/error cores (e.g. a Kafka sink or a stderr-only warn sink).
+func WithSink(name string, minLevel, maxLevel zapcore.Level, writer io.Writer) Option {
+	return func(o *Options) {
+		o.sinks = append(o.sinks, sinkOption{name: name, minLevel: minLevel, maxLevel: maxLevel, writer: writer})
+	}
+}
+
+// WithEncoding selects the log line format: "json" (default), "console" or "logfmt".
+func WithEncoding(enc string) Option {
+	return func(o *Options) {
+		o.encoding = enc
+		o.encodingSet = true
+	}
+}
+
+// WithTimeLayout overrides the time.Format layout used for the time key.
+func WithTimeLayout(layout string) Option {
+	return func(o *Options) { o.timeLayout = layout }
+}
+
+// WithColor enables ANSI-colored level output when os.Stdout is a TTY,
+// whatever the info sink is. It applies to the console and json encodings;
+// logfmt always writes plain level names.
+func WithColor(color bool) Option {
+	return func(o *Options) { o.color = color }
+}
+
+// WithDurationUnit sets the unit durations are logged in, as a whole number of
+// that unit; any remainder is truncated.
+func WithDurationUnit(unit time.Duration) Option {
+	return func(o *Options) { o.durationUnit = unit }
+}
+
+// WithSampling caps repeated identical log lines: the first initial entries
+// in each tick are logged, then one in every thereafter after that. See
+// zapcore.NewSamplerWithOptions for the exact semantics.
+func WithSampling(initial, thereafter int, tick time.Duration) Option {
+	return func(o *Options) {
+		o.samplingSet = true
+		o.samplingInitial = initial
+		o.samplingThereafter = thereafter
+		o.samplingTick = tick
+	}
+}
+
+// WithRateLimit caps the logger at perSecond entries per level, with bursts
+// up to burst, using a token bucket. Entries over budget are dropped rather
+// than blocking the caller; drops are counted and periodically summarized
+// via a "dropped N logs" record. See Logger.MetricsHandler for the counters.
+func WithRateLimit(perSecond, burst int) Option {
+	return func(o *Options) {
+		o.rateLimitSet = true
+		o.rateLimitPerSecond = perSecond
+		o.rateLimitBurst = burst
+	}
+}
+
 func NewLogger(cfg *Config, options ...Option) (l *Logger, err error) {
 	opts := defaultOptions()
 	for _, o := range options {
@@ -84,19 +212,43 @@ func NewLogger(cfg *Config, options ...Option) (l *Logger, err error) {
 	}
 
 	l = &Logger{
-		level: level,
+		level: zap.NewAtomicLevelAt(level),
 		opts:  opts,
 	}
 
-	encoder := l.formatEncoder()
+	rotation := cfg.rotationPolicy()
+	if opts.rotationSet {
+		rotation = opts.rotation
+	}
+
+	infoWriter := opts.infoWriter
+	if !opts.infoWriterSet && cfg.InfoFile != "" {
+		infoWriter = rotation.writer(cfg.InfoFile)
+	}
 
-	infoEnabler := l.infoEnabler()
-	errorEnabler := l.errorEnabler()
+	errorWriter := opts.errorWriter
+	if !opts.errorWriterSet && cfg.ErrorFile != "" {
+		errorWriter = rotation.writer(cfg.ErrorFile)
+	}
 
-	core := zapcore.NewTee(
-		zapcore.NewCore(encoder, zapcore.AddSync(opts.infoWriter), infoEnabler),
-		zapcore.NewCore(encoder, zapcore.AddSync(opts.errorWriter), errorEnabler),
-	)
+	if !opts.encodingSet && cfg.Encoding != "" {
+		opts.encoding = cfg.Encoding
+	}
+
+	l.infoWriter = infoWriter
+	l.errorWriter = errorWriter
+	l.encoder = l.formatEncoder()
+
+	var core zapcore.Core = zapcore.NewTee(l.buildCores(l.level)...)
+
+	if opts.rateLimitSet {
+		l.rateLimitStats = &RateLimitStats{}
+		core = newRateLimitCore(core, opts.rateLimitPerSecond, opts.rateLimitBurst, l.rateLimitStats)
+	}
+
+	if opts.samplingSet {
+		core = zapcore.NewSamplerWithOptions(core, opts.samplingTick, opts.samplingInitial, opts.samplingThereafter)
+	}
 
 	fields := []zapcore.Field{
 		zap.String(Module, l.opts.module),
@@ -105,7 +257,7 @@ func NewLogger(cfg *Config, options ...Option) (l *Logger, err error) {
 
 	l.Logger = zap.New(core,
 		zap.AddCaller(),
-		zap.AddStacktrace(errorEnabler),
+		zap.AddStacktrace(l.errorEnabler()),
 		zap.AddCallerSkip(l.opts.callSkip),
 		zap.Fields(fields...),
 	)
@@ -113,18 +265,46 @@ func NewLogger(cfg *Config, options ...Option) (l *Logger, err error) {
 	return
 }
 
+// buildCores returns the info/error/sink cores built from l's resolved
+// encoder and writers, gated by level rather than l.level. NewLogger uses it
+// with l.level for its own cores; LoggerRegistry uses it with a child's
+// independent AtomicLevel so a named child can be tuned without being capped
+// by l's threshold.
+func (l *Logger) buildCores(level zap.AtomicLevel) []zapcore.Core {
+	cores := []zapcore.Core{
+		zapcore.NewCore(l.encoder, zapcore.AddSync(l.infoWriter), infoEnablerFor(level)),
+		zapcore.NewCore(l.encoder, zapcore.AddSync(l.errorWriter), errorEnablerFor(level)),
+	}
+	for _, s := range l.opts.sinks {
+		cores = append(cores, zapcore.NewCore(l.encoder, zapcore.AddSync(s.writer), s.levelEnabler(level)))
+	}
+	return cores
+}
+
 func (l *Logger) infoEnabler() zap.LevelEnablerFunc {
+	return infoEnablerFor(l.level)
+}
+
+func (l *Logger) errorEnabler() zap.LevelEnablerFunc {
+	return errorEnablerFor(l.level)
+}
+
+// infoEnablerFor/errorEnablerFor split level's range the way the info/error
+// file sinks expect: info and below to one core, warn and above to the
+// other. Factored out of Logger's own enablers so LoggerRegistry can build
+// the same split gated by a child's independent AtomicLevel.
+func infoEnablerFor(level zap.AtomicLevel) zap.LevelEnablerFunc {
 	return zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-		if lvl < l.level {
+		if !level.Enabled(lvl) {
 			return false
 		}
 		return lvl <= zapcore.InfoLevel
 	})
 }
 
-func (l *Logger) errorEnabler() zap.LevelEnablerFunc {
+func errorEnablerFor(level zap.AtomicLevel) zap.LevelEnablerFunc {
 	return zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-		if lvl < l.level {
+		if !level.Enabled(lvl) {
 			return false
 		}
 		return lvl >= zapcore.WarnLevel
@@ -132,28 +312,91 @@ func (l *Logger) errorEnabler() zap.LevelEnablerFunc {
 }
 
 func (l *Logger) formatEncoder() zapcore.Encoder {
-	return zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+	cfg := l.encoderConfig()
+
+	switch l.opts.encoding {
+	case "console":
+		return zapcore.NewConsoleEncoder(cfg)
+	case "logfmt":
+		return newLogfmtEncoder(cfg)
+	default:
+		return zapcore.NewJSONEncoder(cfg)
+	}
+}
+
+func (l *Logger) encoderConfig() zapcore.EncoderConfig {
+	timeLayout := l.opts.timeLayout
+	if timeLayout == "" {
+		timeLayout = "2006-01-02 15:04:05"
+	}
+
+	durationUnit := l.opts.durationUnit
+	if durationUnit == 0 {
+		durationUnit = time.Millisecond
+	}
+
+	levelEncoder := zapcore.CapitalLevelEncoder
+	if l.opts.color && isTerminal(os.Stdout) {
+		levelEncoder = zapcore.CapitalColorLevelEncoder
+	}
+
+	return zapcore.EncoderConfig{
 		MessageKey:    "msg",
 		LevelKey:      "level",
-		EncodeLevel:   zapcore.CapitalLevelEncoder,
+		EncodeLevel:   levelEncoder,
 		TimeKey:       "time",
+		NameKey:       "logger",
 		CallerKey:     "file",
 		FunctionKey:   "func",
 		StacktraceKey: "stack",
 		EncodeTime: func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
-			enc.AppendString(t.Format("2006-01-02 15:04:05"))
+			enc.AppendString(t.Format(timeLayout))
 		},
 		EncodeCaller: zapcore.ShortCallerEncoder,
 		EncodeDuration: func(d time.Duration, enc zapcore.PrimitiveArrayEncoder) {
-			enc.AppendInt64(int64(d) / 1000000)
+			enc.AppendInt64(int64(d) / int64(durationUnit))
 		},
-	})
+	}
 }
 
 func (l *Logger) GetLevel() zapcore.Level {
+	return l.level.Level()
+}
+
+// SetLevel adjusts the logger's threshold at runtime; it takes effect on
+// every core, including those added via WithSink.
+func (l *Logger) SetLevel(level string) error {
+	lvl, err := zapLevel(level)
+	if err != nil {
+		return err
+	}
+	l.level.SetLevel(lvl)
+	return nil
+}
+
+// LevelHandler exposes l's AtomicLevel as an http.Handler: GET reports the
+// current level, PUT {"level":"debug"} changes it without a restart.
+func (l *Logger) LevelHandler() http.Handler {
 	return l.level
 }
 
+// MetricsHandler exposes l's rate-limit accepted/dropped counters, per level,
+// as a JSON GET endpoint. It 404s unless WithRateLimit was configured.
+func (l *Logger) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if l.rateLimitStats == nil {
+			http.Error(w, "rate limiting is not configured", http.StatusNotFound)
+			return
+		}
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(l.rateLimitStats.snapshot())
+	})
+}
+
 func zapLevel(level string) (zapcore.Level, error) {
 	switch level {
 	case "debug", "DEBUG":
@@ -196,20 +439,14 @@ func (l *Logger) Fatal(ctx context.Context, msg string, fields ...zap.Field) {
 }
 
 func (l *Logger) extractFields(ctx context.Context, fields ...zap.Field) []zap.Field {
-	fieldsMap, _ := conversion.StructToMap(ValueHTTPFields(ctx))
-	target := make(map[string]zap.Field, len(fieldsMap))
-	for k, v := range fieldsMap {
-		target[k] = zap.Reflect(k, v)
-	}
-
-	for _, f := range fields {
-		target[f.Key] = f
-	}
+	combined := Fields(ctx)
 
-	new := make([]zap.Field, 0)
-	for _, f := range target {
-		new = append(new, f)
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		combined = mergeFields(combined, []zap.Field{
+			zap.String(TraceID, sc.TraceID().String()),
+			zap.String(SpanID, sc.SpanID().String()),
+		})
 	}
 
-	return new
+	return mergeFields(combined, fields)
 }
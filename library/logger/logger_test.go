@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_SetLevel(t *testing.T) {
+	convey.Convey("TestLogger_SetLevel", t, func() {
+		convey.Convey("success", func() {
+			l, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(io.Discard), WithErrorWriter(io.Discard))
+			assert.Equal(t, err, nil)
+			assert.Equal(t, l.GetLevel(), zapcore.InfoLevel)
+
+			err = l.SetLevel("debug")
+			assert.Equal(t, err, nil)
+			assert.Equal(t, l.GetLevel(), zapcore.DebugLevel)
+		})
+
+		convey.Convey("invalid level", func() {
+			l, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(io.Discard), WithErrorWriter(io.Discard))
+			assert.Equal(t, err, nil)
+
+			err = l.SetLevel("not-a-level")
+			assert.NotEqual(t, err, nil)
+			assert.Equal(t, l.GetLevel(), zapcore.InfoLevel)
+		})
+	})
+}
+
+func TestLogger_SetLevel_Concurrent(t *testing.T) {
+	convey.Convey("TestLogger_SetLevel_Concurrent", t, func() {
+		convey.Convey("flips under concurrent writers without racing", func() {
+			l, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(io.Discard), WithErrorWriter(io.Discard))
+			assert.Equal(t, err, nil)
+
+			ctx := context.Background()
+
+			var wg sync.WaitGroup
+			for i := 0; i < 50; i++ {
+				wg.Add(2)
+				go func(i int) {
+					defer wg.Done()
+					if i%2 == 0 {
+						_ = l.SetLevel("debug")
+					} else {
+						_ = l.SetLevel("info")
+					}
+				}(i)
+				go func() {
+					defer wg.Done()
+					l.Info(ctx, "concurrent write")
+				}()
+			}
+			wg.Wait()
+		})
+	})
+}
+
+func TestLogger_TraceCorrelation(t *testing.T) {
+	convey.Convey("TestLogger_TraceCorrelation", t, func() {
+		convey.Convey("a valid span in context adds trace_id and span_id", func() {
+			var buf bytes.Buffer
+			l, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(&buf), WithErrorWriter(io.Discard))
+			assert.Equal(t, err, nil)
+
+			sc := trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    [16]byte{1},
+				SpanID:     [8]byte{2},
+				TraceFlags: trace.FlagsSampled,
+			})
+			ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+			l.Info(ctx, "hello")
+			assert.Contains(t, buf.String(), `"trace_id":"`+sc.TraceID().String()+`"`)
+			assert.Contains(t, buf.String(), `"span_id":"`+sc.SpanID().String()+`"`)
+		})
+
+		convey.Convey("no span in context omits trace_id and span_id", func() {
+			var buf bytes.Buffer
+			l, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(&buf), WithErrorWriter(io.Discard))
+			assert.Equal(t, err, nil)
+
+			l.Info(context.Background(), "hello")
+			assert.NotContains(t, buf.String(), "trace_id")
+			assert.NotContains(t, buf.String(), "span_id")
+		})
+
+		convey.Convey("fields attached via WithFields are merged alongside span correlation", func() {
+			var buf bytes.Buffer
+			l, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(&buf), WithErrorWriter(io.Discard))
+			assert.Equal(t, err, nil)
+
+			sc := trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    [16]byte{1},
+				SpanID:     [8]byte{2},
+				TraceFlags: trace.FlagsSampled,
+			})
+			ctx := trace.ContextWithSpanContext(context.Background(), sc)
+			ctx = WithFields(ctx, zapcore.Field{Key: RequestID, Type: zapcore.StringType, String: "req-1"})
+
+			l.Info(ctx, "hello")
+			assert.Contains(t, buf.String(), `"request_id":"req-1"`)
+			assert.Contains(t, buf.String(), `"trace_id":"`+sc.TraceID().String()+`"`)
+		})
+	})
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/why444216978/go-util/snowflake"
+	"go.uber.org/zap"
+
+	"github.com/why444216978/gin-api/library/logger"
+)
+
+// RequestIDHeader is the header request ids are read from and echoed on.
+const RequestIDHeader = "X-Request-Id"
+
+// Logging seeds request_id and any caller-supplied baggage into the request
+// context; trace_id/span_id are picked up automatically by Logger from the
+// active OpenTelemetry span. Every logger.Logger call downstream of this
+// middleware therefore includes them without extra plumbing.
+func Logging(baggage ...zap.Field) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			requestID = snowflake.Generate().String()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		fields := append([]zap.Field{zap.String(logger.RequestID, requestID)}, baggage...)
+		ctx := logger.WithFields(c.Request.Context(), fields...)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
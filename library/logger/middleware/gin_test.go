@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/why444216978/gin-api/library/logger"
+)
+
+func TestLogging(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	convey.Convey("TestLogging", t, func() {
+		convey.Convey("generates a request id and echoes it when the caller sent none", func() {
+			var seen string
+			r := gin.New()
+			r.Use(Logging())
+			r.GET("/ping", func(c *gin.Context) {
+				seen = logger.Fields(c.Request.Context())[0].String
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.NotEqual(t, rec.Header().Get(RequestIDHeader), "")
+			assert.Equal(t, seen, rec.Header().Get(RequestIDHeader))
+		})
+
+		convey.Convey("echoes back a caller-supplied request id instead of generating one", func() {
+			r := gin.New()
+			r.Use(Logging())
+			r.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			req.Header.Set(RequestIDHeader, "caller-id")
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, rec.Header().Get(RequestIDHeader), "caller-id")
+		})
+
+		convey.Convey("baggage fields are attached to the request context alongside request_id", func() {
+			var fields []zap.Field
+			r := gin.New()
+			r.Use(Logging(zap.String("caller", "orders-svc")))
+			r.GET("/ping", func(c *gin.Context) {
+				fields = logger.Fields(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Len(t, fields, 2)
+			assert.Equal(t, fields[0].Key, logger.RequestID)
+			assert.Equal(t, fields[1].Key, "caller")
+			assert.Equal(t, fields[1].String, "orders-svc")
+		})
+	})
+}
@@ -0,0 +1,159 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// rateLimitSummaryInterval bounds how often a dropped-logs summary record is
+// emitted for a single level while it's being rate-limited.
+const rateLimitSummaryInterval = 10 * time.Second
+
+// numLevels spans zapcore's Debug..Fatal range, used to size per-level arrays.
+const numLevels = int(zapcore.FatalLevel) - int(zapcore.DebugLevel) + 1
+
+func levelIndex(level zapcore.Level) int {
+	return int(level) - int(zapcore.DebugLevel)
+}
+
+// LevelCount is a point-in-time accepted/dropped snapshot for one level.
+type LevelCount struct {
+	Accepted uint64 `json:"accepted"`
+	Dropped  uint64 `json:"dropped"`
+}
+
+// RateLimitStats tracks accepted/dropped entry counts per level for a
+// rate-limited Logger, read via Logger.MetricsHandler.
+type RateLimitStats struct {
+	accepted [numLevels]uint64
+	dropped  [numLevels]uint64
+}
+
+func (s *RateLimitStats) snapshot() map[string]LevelCount {
+	out := make(map[string]LevelCount, numLevels)
+	for i := 0; i < numLevels; i++ {
+		level := zapcore.Level(i + int(zapcore.DebugLevel))
+		out[level.String()] = LevelCount{
+			Accepted: atomic.LoadUint64(&s.accepted[i]),
+			Dropped:  atomic.LoadUint64(&s.dropped[i]),
+		}
+	}
+	return out
+}
+
+// tokenBucket is a minimal token-bucket rate limiter: tokens refill at
+// perSecond and cap at burst.
+type tokenBucket struct {
+	mu        sync.Mutex
+	tokens    float64
+	burst     float64
+	perSecond float64
+	last      time.Time
+}
+
+func newTokenBucket(perSecond, burst float64) *tokenBucket {
+	return &tokenBucket{tokens: burst, burst: burst, perSecond: perSecond}
+}
+
+func (b *tokenBucket) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if b.last.IsZero() {
+		b.last = now
+	}
+	b.tokens += now.Sub(b.last).Seconds() * b.perSecond
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// rateLimitState is the per-level token-bucket and summary-throttling state
+// shared by a rateLimitCore and every core returned from its With method.
+type rateLimitState struct {
+	stats   *RateLimitStats
+	buckets [numLevels]*tokenBucket
+
+	summaryMu    [numLevels]sync.Mutex
+	lastSummary  [numLevels]time.Time
+	sinceSummary [numLevels]uint64
+}
+
+// rateLimitCore wraps a zapcore.Core with a per-level token bucket: entries
+// over budget are dropped and counted instead of blocking the caller, and a
+// "dropped N logs" summary record is written through at most once every
+// rateLimitSummaryInterval per level.
+type rateLimitCore struct {
+	zapcore.Core
+	state *rateLimitState
+}
+
+func newRateLimitCore(core zapcore.Core, perSecond, burst int, stats *RateLimitStats) *rateLimitCore {
+	state := &rateLimitState{stats: stats}
+	for i := range state.buckets {
+		state.buckets[i] = newTokenBucket(float64(perSecond), float64(burst))
+	}
+	return &rateLimitCore{Core: core, state: state}
+}
+
+// Check consults the token bucket before the entry is admitted, not just
+// before it's written, so a throttled entry never reaches ce.AddCore and
+// zap skips caller/stacktrace capture for it entirely, the same way its
+// own sampler core does.
+func (c *rateLimitCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Core.Enabled(ent.Level) {
+		return ce
+	}
+
+	idx := levelIndex(ent.Level)
+	state := c.state
+
+	if state.buckets[idx].allow() {
+		atomic.AddUint64(&state.stats.accepted[idx], 1)
+		return c.Core.Check(ent, ce)
+	}
+
+	atomic.AddUint64(&state.stats.dropped[idx], 1)
+	dropped := atomic.AddUint64(&state.sinceSummary[idx], 1)
+
+	state.summaryMu[idx].Lock()
+	due := time.Since(state.lastSummary[idx]) >= rateLimitSummaryInterval
+	if due {
+		state.lastSummary[idx] = time.Now()
+		atomic.StoreUint64(&state.sinceSummary[idx], 0)
+	}
+	state.summaryMu[idx].Unlock()
+
+	if due {
+		summary := zapcore.Entry{
+			Level:   ent.Level,
+			Time:    ent.Time,
+			Message: fmt.Sprintf("dropped %d logs due to rate limiting", dropped),
+		}
+		if sce := c.Core.Check(summary, nil); sce != nil {
+			sce.Write()
+		}
+	}
+
+	return ce
+}
+
+func (c *rateLimitCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, fields)
+}
+
+func (c *rateLimitCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitCore{Core: c.Core.With(fields), state: c.state}
+}
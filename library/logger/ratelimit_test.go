@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger_RateLimit(t *testing.T) {
+	convey.Convey("TestLogger_RateLimit", t, func() {
+		convey.Convey("drops entries over budget and counts them", func() {
+			l, err := NewLogger(&Config{Level: "info"},
+				WithInfoWriter(io.Discard),
+				WithErrorWriter(io.Discard),
+				WithRateLimit(1, 1),
+			)
+			assert.Equal(t, err, nil)
+
+			ctx := context.Background()
+			for i := 0; i < 10; i++ {
+				l.Info(ctx, "burst")
+			}
+
+			stats := l.rateLimitStats.snapshot()
+			assert.True(t, stats["info"].Accepted >= 1)
+			assert.True(t, stats["info"].Dropped > 0)
+		})
+
+		convey.Convey("MetricsHandler 404s without WithRateLimit", func() {
+			l, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(io.Discard), WithErrorWriter(io.Discard))
+			assert.Equal(t, err, nil)
+			assert.Equal(t, l.rateLimitStats == nil, true)
+
+			req := httptest.NewRequest(http.MethodGet, "/debug/log/metrics/service", nil)
+			rec := httptest.NewRecorder()
+			l.MetricsHandler().ServeHTTP(rec, req)
+			assert.Equal(t, rec.Code, http.StatusNotFound)
+		})
+
+		convey.Convey("MetricsHandler reports accepted/dropped counts as JSON with WithRateLimit", func() {
+			l, err := NewLogger(&Config{Level: "info"},
+				WithInfoWriter(io.Discard),
+				WithErrorWriter(io.Discard),
+				WithRateLimit(1, 1),
+			)
+			assert.Equal(t, err, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/debug/log/metrics/service", nil)
+			rec := httptest.NewRecorder()
+			l.MetricsHandler().ServeHTTP(rec, req)
+			assert.Equal(t, rec.Code, http.StatusOK)
+			assert.Contains(t, rec.Body.String(), "accepted")
+		})
+
+		convey.Convey("throttled Warn+ entries skip stacktrace capture instead of just the write", func() {
+			var buf bytes.Buffer
+			l, err := NewLogger(&Config{Level: "info"},
+				WithInfoWriter(io.Discard),
+				WithErrorWriter(&buf),
+				WithRateLimit(0, 0),
+			)
+			assert.Equal(t, err, nil)
+
+			ctx := context.Background()
+			for i := 0; i < 10; i++ {
+				l.Warn(ctx, "burst")
+			}
+
+			stats := l.rateLimitStats.snapshot()
+			assert.Equal(t, stats["warn"].Accepted, uint64(0))
+			assert.True(t, stats["warn"].Dropped > 0)
+			assert.NotContains(t, buf.String(), "\"stack\"")
+		})
+	})
+}
@@ -0,0 +1,207 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogLevelsEnv is the environment variable parsed by NewLoggerRegistry to
+// seed per-module level overrides, e.g. "LOG_LEVELS=http=debug,orm=warn".
+const LogLevelsEnv = "LOG_LEVELS"
+
+// LoggerRegistry hands out named child loggers derived from a base Logger,
+// each built from the base's encoder/writers with its own independent
+// AtomicLevel so one module's verbosity can be tuned, wider or narrower,
+// without affecting the rest.
+type LoggerRegistry struct {
+	mu        sync.RWMutex
+	base      *Logger
+	loggers   map[string]*Logger
+	overrides map[string]zapcore.Level
+}
+
+// NewLoggerRegistry creates a registry whose child loggers derive from base.
+// LOG_LEVELS, if set, overrides the starting level of any module named in
+// it once that module is first retrieved via Get.
+func NewLoggerRegistry(base *Logger) *LoggerRegistry {
+	return &LoggerRegistry{
+		base:      base,
+		loggers:   make(map[string]*Logger),
+		overrides: parseLogLevelsEnv(os.Getenv(LogLevelsEnv)),
+	}
+}
+
+func parseLogLevelsEnv(raw string) map[string]zapcore.Level {
+	overrides := make(map[string]zapcore.Level)
+	for _, pair := range strings.Split(raw, ",") {
+		module, levelStr, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+		level, err := zapLevel(strings.TrimSpace(levelStr))
+		if err != nil {
+			continue
+		}
+		overrides[strings.TrimSpace(module)] = level
+	}
+	return overrides
+}
+
+// Get returns the named child logger, creating it on first use at the base
+// logger's current level, or its LOG_LEVELS override if one was given.
+func (r *LoggerRegistry) Get(module string) *Logger {
+	r.mu.RLock()
+	l, ok := r.loggers[module]
+	r.mu.RUnlock()
+	if ok {
+		return l
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if l, ok := r.loggers[module]; ok {
+		return l
+	}
+
+	level := r.base.GetLevel()
+	if override, ok := r.overrides[module]; ok {
+		level = override
+	}
+
+	l = r.newChild(module, level)
+	r.loggers[module] = l
+	return l
+}
+
+// newChild builds module's cores directly from the base logger's encoder and
+// writers, gated solely by the child's own AtomicLevel. Wrapping the base's
+// already-level-gated core (as an earlier version did) could only ever
+// narrow a module's verbosity, never widen it past the base's threshold.
+//
+// The rate-limit and sampling layers, if the base has them, are re-wrapped
+// around the child's own cores rather than shared with the base: a module
+// raised to debug is exactly the case most likely to need storm protection,
+// so it gets its own token bucket/sampler instead of inheriting none.
+func (r *LoggerRegistry) newChild(module string, level zapcore.Level) *Logger {
+	atomicLevel := zap.NewAtomicLevelAt(level)
+
+	child := &Logger{
+		opts:        r.base.opts,
+		level:       atomicLevel,
+		encoder:     r.base.encoder,
+		infoWriter:  r.base.infoWriter,
+		errorWriter: r.base.errorWriter,
+	}
+
+	var core zapcore.Core = zapcore.NewTee(r.base.buildCores(atomicLevel)...)
+
+	if r.base.opts.rateLimitSet {
+		child.rateLimitStats = &RateLimitStats{}
+		core = newRateLimitCore(core, r.base.opts.rateLimitPerSecond, r.base.opts.rateLimitBurst, child.rateLimitStats)
+	}
+
+	if r.base.opts.samplingSet {
+		core = zapcore.NewSamplerWithOptions(core, r.base.opts.samplingTick, r.base.opts.samplingInitial, r.base.opts.samplingThereafter)
+	}
+
+	fields := []zapcore.Field{
+		zap.String(Module, module),
+		zap.String(SericeName, r.base.opts.serviceName),
+	}
+
+	child.Logger = zap.New(core,
+		zap.AddCaller(),
+		zap.AddStacktrace(errorEnablerFor(atomicLevel)),
+		zap.AddCallerSkip(r.base.opts.callSkip),
+		zap.Fields(fields...),
+	).Named(module)
+
+	return child
+}
+
+// SetLevel updates the level of every registered module whose name matches
+// pattern, a path.Match glob (e.g. "db.*"); modules not yet created via Get
+// are unaffected, even if they would later match.
+func (r *LoggerRegistry) SetLevel(pattern, level string) error {
+	lvl, err := zapLevel(level)
+	if err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := false
+	for module, l := range r.loggers {
+		ok, err := path.Match(pattern, module)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		l.level.SetLevel(lvl)
+		matched = true
+	}
+	if !matched {
+		return fmt.Errorf("logger: no registered module matches %q", pattern)
+	}
+	return nil
+}
+
+// Modules lists every registered module name, sorted.
+func (r *LoggerRegistry) Modules() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	modules := make([]string, 0, len(r.loggers))
+	for module := range r.loggers {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	return modules
+}
+
+// RegistryHandler exposes r as an http.Handler: GET lists every registered
+// module and its current level, PUT {"module":"db.*","level":"debug"}
+// applies SetLevel to every registered module matching the glob.
+func (r *LoggerRegistry) RegistryHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			r.mu.RLock()
+			levels := make(map[string]string, len(r.loggers))
+			for module, l := range r.loggers {
+				levels[module] = l.GetLevel().String()
+			}
+			r.mu.RUnlock()
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(levels)
+		case http.MethodPut:
+			var body struct {
+				Module string `json:"module"`
+				Level  string `json:"level"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if err := r.SetLevel(body.Module, body.Level); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLoggerRegistry(t *testing.T) {
+	convey.Convey("TestLoggerRegistry", t, func() {
+		base, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(io.Discard), WithErrorWriter(io.Discard))
+		assert.Equal(t, err, nil)
+
+		convey.Convey("Get creates a child at the base level and caches it", func() {
+			reg := NewLoggerRegistry(base)
+
+			orm := reg.Get("orm")
+			assert.Equal(t, orm.GetLevel(), zapcore.InfoLevel)
+			assert.Equal(t, reg.Get("orm"), orm)
+		})
+
+		convey.Convey("SetLevel matches registered modules by glob independently of the base", func() {
+			reg := NewLoggerRegistry(base)
+			dbUsers := reg.Get("db.users")
+			dbOrders := reg.Get("db.orders")
+			httpLogger := reg.Get("http")
+
+			err := reg.SetLevel("db.*", "debug")
+			assert.Equal(t, err, nil)
+			assert.Equal(t, dbUsers.GetLevel(), zapcore.DebugLevel)
+			assert.Equal(t, dbOrders.GetLevel(), zapcore.DebugLevel)
+			assert.Equal(t, httpLogger.GetLevel(), zapcore.InfoLevel)
+			assert.Equal(t, base.GetLevel(), zapcore.InfoLevel)
+		})
+
+		convey.Convey("SetLevel errors when no registered module matches", func() {
+			reg := NewLoggerRegistry(base)
+			reg.Get("orm")
+
+			err := reg.SetLevel("nope.*", "debug")
+			assert.NotEqual(t, err, nil)
+		})
+
+		convey.Convey("LOG_LEVELS seeds a module's starting level", func() {
+			assert.Equal(t, os.Setenv("LOG_LEVELS", "orm=warn, http=debug"), nil)
+			defer os.Unsetenv("LOG_LEVELS")
+
+			reg := NewLoggerRegistry(base)
+			assert.Equal(t, reg.Get("orm").GetLevel(), zapcore.WarnLevel)
+			assert.Equal(t, reg.Get("http").GetLevel(), zapcore.DebugLevel)
+			assert.Equal(t, reg.Get("rpc").GetLevel(), zapcore.InfoLevel)
+		})
+
+		convey.Convey("RegistryHandler lists and mutates registered modules", func() {
+			reg := NewLoggerRegistry(base)
+			reg.Get("orm")
+			handler := reg.RegistryHandler()
+
+			get := httptest.NewRequest(http.MethodGet, "/debug/log/levels", nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, get)
+			assert.Equal(t, rec.Code, http.StatusOK)
+
+			put := httptest.NewRequest(http.MethodPut, "/debug/log/levels", strings.NewReader(`{"module":"orm","level":"debug"}`))
+			rec = httptest.NewRecorder()
+			handler.ServeHTTP(rec, put)
+			assert.Equal(t, rec.Code, http.StatusOK)
+			assert.Equal(t, reg.Get("orm").GetLevel(), zapcore.DebugLevel)
+		})
+
+		convey.Convey("a module raised above the base level actually writes, not just reports the level", func() {
+			var infoBuf bytes.Buffer
+			raised, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(&infoBuf), WithErrorWriter(io.Discard))
+			assert.Equal(t, err, nil)
+
+			reg := NewLoggerRegistry(raised)
+			orm := reg.Get("orm")
+			assert.Equal(t, reg.SetLevel("orm", "debug"), nil)
+
+			orm.Debug(context.Background(), "query executed")
+			assert.Contains(t, infoBuf.String(), "query executed")
+		})
+
+		convey.Convey("a child's output is attributable to its module via the logger key", func() {
+			var infoBuf bytes.Buffer
+			named, err := NewLogger(&Config{Level: "info"}, WithInfoWriter(&infoBuf), WithErrorWriter(io.Discard))
+			assert.Equal(t, err, nil)
+
+			reg := NewLoggerRegistry(named)
+			reg.Get("orm").Info(context.Background(), "hello")
+
+			assert.Contains(t, infoBuf.String(), `"logger":"orm"`)
+		})
+
+		convey.Convey("a child inherits the base's rate limit instead of bypassing it", func() {
+			limited, err := NewLogger(&Config{Level: "info"},
+				WithInfoWriter(io.Discard),
+				WithErrorWriter(io.Discard),
+				WithRateLimit(0, 0),
+			)
+			assert.Equal(t, err, nil)
+
+			reg := NewLoggerRegistry(limited)
+			orm := reg.Get("orm")
+			assert.NotEqual(t, orm.rateLimitStats, nil)
+
+			ctx := context.Background()
+			for i := 0; i < 20; i++ {
+				orm.Info(ctx, "query executed")
+			}
+
+			stats := orm.rateLimitStats.snapshot()
+			assert.Equal(t, stats["info"].Accepted, uint64(0))
+			assert.True(t, stats["info"].Dropped > 0)
+
+			baseStats := limited.rateLimitStats.snapshot()
+			assert.Equal(t, baseStats["info"].Accepted, uint64(0))
+			assert.Equal(t, baseStats["info"].Dropped, uint64(0))
+		})
+	})
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// RotationPolicy controls how an on-disk log file is split, retained and compressed.
+type RotationPolicy struct {
+	MaxSizeMB  int
+	MaxAgeDays int
+	MaxBackups int
+	Compress   bool
+	LocalTime  bool
+}
+
+func defaultRotationPolicy() RotationPolicy {
+	return RotationPolicy{
+		MaxSizeMB:  100,
+		MaxAgeDays: 7,
+		MaxBackups: 10,
+		Compress:   false,
+		LocalTime:  true,
+	}
+}
+
+// rotationPolicy builds a RotationPolicy from Config, falling back to the
+// defaults for any field left at its zero value.
+func (cfg *Config) rotationPolicy() RotationPolicy {
+	p := defaultRotationPolicy()
+	if cfg.MaxSizeMB > 0 {
+		p.MaxSizeMB = cfg.MaxSizeMB
+	}
+	if cfg.MaxAgeDays > 0 {
+		p.MaxAgeDays = cfg.MaxAgeDays
+	}
+	if cfg.MaxBackups > 0 {
+		p.MaxBackups = cfg.MaxBackups
+	}
+	p.Compress = cfg.Compress
+	return p
+}
+
+// writer returns a lumberjack-backed rotating writer for filename under this policy.
+func (p RotationPolicy) writer(filename string) *lumberjack.Logger {
+	return &lumberjack.Logger{
+		Filename:   filename,
+		MaxSize:    p.MaxSizeMB,
+		MaxAge:     p.MaxAgeDays,
+		MaxBackups: p.MaxBackups,
+		Compress:   p.Compress,
+		LocalTime:  p.LocalTime,
+	}
+}
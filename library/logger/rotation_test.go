@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger_WithRotation(t *testing.T) {
+	convey.Convey("TestLogger_WithRotation", t, func() {
+		convey.Convey("overrides the policy derived from Config", func() {
+			l, err := NewLogger(&Config{Level: "info", MaxSizeMB: 1},
+				WithInfoWriter(&bytes.Buffer{}),
+				WithErrorWriter(&bytes.Buffer{}),
+				WithRotation(RotationPolicy{MaxSizeMB: 5, MaxAgeDays: 1, MaxBackups: 2}),
+			)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, l.opts.rotation.MaxSizeMB, 5)
+			assert.Equal(t, l.opts.rotationSet, true)
+		})
+
+		convey.Convey("writes a rotating file when InfoFile/ErrorFile are set without an explicit writer", func() {
+			dir := t.TempDir()
+			infoFile := filepath.Join(dir, "info.log")
+
+			l, err := NewLogger(&Config{Level: "info", InfoFile: infoFile}, WithErrorWriter(&bytes.Buffer{}))
+			assert.Equal(t, err, nil)
+
+			l.Info(context.Background(), "hello")
+
+			contents, err := os.ReadFile(infoFile)
+			assert.Equal(t, err, nil)
+			assert.Contains(t, string(contents), "hello")
+		})
+	})
+}
+
+func TestRotationPolicy_writer(t *testing.T) {
+	convey.Convey("TestRotationPolicy_writer", t, func() {
+		convey.Convey("builds a lumberjack.Logger from the policy", func() {
+			dir := t.TempDir()
+			filename := filepath.Join(dir, "app.log")
+
+			p := RotationPolicy{MaxSizeMB: 10, MaxAgeDays: 3, MaxBackups: 1, Compress: true}
+			w := p.writer(filename)
+
+			assert.Equal(t, w.Filename, filename)
+			assert.Equal(t, w.MaxSize, 10)
+			assert.Equal(t, w.MaxAge, 3)
+			assert.Equal(t, w.MaxBackups, 1)
+			assert.Equal(t, w.Compress, true)
+		})
+	})
+}
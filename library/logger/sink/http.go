@@ -0,0 +1,92 @@
+package sink
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTPOption configures an HTTPSink beyond the required endpoint URL.
+type HTTPOption func(*httpOptions)
+
+type httpOptions struct {
+	client   *http.Client
+	headers  map[string]string
+	batching Config
+}
+
+func defaultHTTPOptions() httpOptions {
+	return httpOptions{
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// WithHTTPClient overrides the default client (5s timeout).
+func WithHTTPClient(client *http.Client) HTTPOption {
+	return func(o *httpOptions) { o.client = client }
+}
+
+// WithHTTPHeader sets an extra header sent with every batch POST.
+func WithHTTPHeader(key, value string) HTTPOption {
+	return func(o *httpOptions) {
+		if o.headers == nil {
+			o.headers = map[string]string{}
+		}
+		o.headers[key] = value
+	}
+}
+
+// WithHTTPBatching overrides the default batching/backpressure policy.
+func WithHTTPBatching(cfg Config) HTTPOption {
+	return func(o *httpOptions) { o.batching = cfg }
+}
+
+// HTTPSink ships batches of log lines to a collector endpoint as
+// newline-delimited POST bodies, implementing zapcore.WriteSyncer so it can
+// be passed straight to logger.WithSink.
+type HTTPSink struct {
+	*asyncSink
+}
+
+// NewHTTPSink returns an HTTPSink posting batches to endpoint; the caller
+// must Close it on shutdown to drain pending entries.
+func NewHTTPSink(endpoint string, opts ...HTTPOption) *HTTPSink {
+	o := defaultHTTPOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	s := &HTTPSink{}
+	s.asyncSink = newAsyncSink(&httpSender{endpoint: endpoint, client: o.client, headers: o.headers}, o.batching)
+	return s
+}
+
+type httpSender struct {
+	endpoint string
+	client   *http.Client
+	headers  map[string]string
+}
+
+func (s *httpSender) Send(batch [][]byte) error {
+	body := bytes.Join(batch, []byte("\n"))
+
+	req, err := http.NewRequest(http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sink: collector returned status %d", resp.StatusCode)
+	}
+	return nil
+}
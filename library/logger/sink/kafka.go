@@ -0,0 +1,115 @@
+//go:build kafka
+
+package sink
+
+// KafkaSink depends on github.com/IBM/sarama, a sizeable dependency most
+// consumers of this package never need, so this file only builds with
+// -tags kafka; importing logger/sink for HTTPSink alone stays sarama-free.
+
+import "github.com/IBM/sarama"
+
+// KafkaOption configures a KafkaSink beyond the required brokers/topic.
+type KafkaOption func(*kafkaOptions)
+
+type kafkaOptions struct {
+	acks        sarama.RequiredAcks
+	compression sarama.CompressionCodec
+	batching    Config
+}
+
+func defaultKafkaOptions() kafkaOptions {
+	return kafkaOptions{
+		acks:        sarama.WaitForLocal,
+		compression: sarama.CompressionSnappy,
+	}
+}
+
+// WithKafkaAcks sets the producer acknowledgement level; ack is one of
+// "none", "local" (default) or "all".
+func WithKafkaAcks(ack string) KafkaOption {
+	return func(o *kafkaOptions) {
+		switch ack {
+		case "none":
+			o.acks = sarama.NoResponse
+		case "all":
+			o.acks = sarama.WaitForAll
+		default:
+			o.acks = sarama.WaitForLocal
+		}
+	}
+}
+
+// WithKafkaCompression sets the producer compression codec; codec is one of
+// "none", "gzip", "snappy" (default), "lz4" or "zstd".
+func WithKafkaCompression(codec string) KafkaOption {
+	return func(o *kafkaOptions) {
+		switch codec {
+		case "none":
+			o.compression = sarama.CompressionNone
+		case "gzip":
+			o.compression = sarama.CompressionGZIP
+		case "lz4":
+			o.compression = sarama.CompressionLZ4
+		case "zstd":
+			o.compression = sarama.CompressionZSTD
+		default:
+			o.compression = sarama.CompressionSnappy
+		}
+	}
+}
+
+// WithKafkaBatching overrides the default batching/backpressure policy.
+func WithKafkaBatching(cfg Config) KafkaOption {
+	return func(o *kafkaOptions) { o.batching = cfg }
+}
+
+// KafkaSink ships batches of log lines to a Kafka topic, implementing
+// zapcore.WriteSyncer so it can be passed straight to logger.WithSink.
+type KafkaSink struct {
+	*asyncSink
+	producer sarama.SyncProducer
+}
+
+// NewKafkaSink dials brokers and returns a KafkaSink publishing to topic; the
+// caller must Close it on shutdown to drain pending entries.
+func NewKafkaSink(brokers []string, topic string, opts ...KafkaOption) (*KafkaSink, error) {
+	o := defaultKafkaOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cfg := sarama.NewConfig()
+	cfg.Producer.RequiredAcks = o.acks
+	cfg.Producer.Compression = o.compression
+	cfg.Producer.Return.Successes = true
+
+	producer, err := sarama.NewSyncProducer(brokers, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &KafkaSink{producer: producer}
+	s.asyncSink = newAsyncSink(&kafkaSender{producer: producer, topic: topic}, o.batching)
+	return s, nil
+}
+
+// Close drains queued entries, then closes the underlying producer.
+func (s *KafkaSink) Close() error {
+	if err := s.asyncSink.Close(); err != nil {
+		return err
+	}
+	return s.producer.Close()
+}
+
+type kafkaSender struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func (s *kafkaSender) Send(batch [][]byte) error {
+	messages := make([]*sarama.ProducerMessage, len(batch))
+	for i, line := range batch {
+		messages[i] = &sarama.ProducerMessage{Topic: s.topic, Value: sarama.ByteEncoder(line)}
+	}
+	return s.producer.SendMessages(messages)
+}
@@ -0,0 +1,211 @@
+// Package sink provides async, batching zapcore.WriteSyncer implementations
+// (KafkaSink, HTTPSink) for shipping log entries to a centralized collector
+// without blocking the logging caller.
+package sink
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Config controls the batching/backpressure behavior shared by every sink.
+type Config struct {
+	// FlushInterval is the longest a line waits in the queue before being
+	// sent, even if BatchSize hasn't been reached. Default 1s.
+	FlushInterval time.Duration
+	// BatchSize is the number of lines sent together once reached. Default 100.
+	BatchSize int
+	// MaxQueue bounds the number of lines buffered ahead of a flush. Default 1000.
+	MaxQueue int
+	// Blocking makes Write block when the queue is full instead of dropping
+	// the line. Default false (drop).
+	Blocking bool
+	// MaxRetries is the number of resend attempts for a failed batch before
+	// it's written to stderr instead. Default 3.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry; it doubles on each
+	// subsequent attempt up to MaxBackoff. Default 100ms.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the retry backoff. Default 5s.
+	MaxBackoff time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = time.Second
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	if c.MaxQueue <= 0 {
+		c.MaxQueue = 1000
+	}
+	if c.MaxRetries <= 0 {
+		c.MaxRetries = 3
+	}
+	if c.InitialBackoff <= 0 {
+		c.InitialBackoff = 100 * time.Millisecond
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = 5 * time.Second
+	}
+	return c
+}
+
+// Sender ships one batch of already-formatted log lines to a backend (Kafka
+// broker, HTTP collector, ...). An error fails the whole batch for retry.
+type Sender interface {
+	Send(batch [][]byte) error
+}
+
+// Stats is a point-in-time view of an asyncSink's counters, for monitoring.
+type Stats struct {
+	Sent    uint64
+	Dropped uint64
+	Failed  uint64
+}
+
+// asyncSink batches Write calls in a background goroutine and hands them to
+// a Sender, retrying with exponential backoff. It never blocks the caller
+// longer than Config.Blocking allows, and falls back to stderr for a batch
+// that exhausts its retries, so a down broker can never panic the caller.
+type asyncSink struct {
+	cfg    Config
+	sender Sender
+
+	queue chan []byte
+	done  chan struct{}
+	wg    sync.WaitGroup
+
+	sent, dropped, failed uint64
+}
+
+func newAsyncSink(sender Sender, cfg Config) *asyncSink {
+	cfg = cfg.withDefaults()
+	s := &asyncSink{
+		cfg:    cfg,
+		sender: sender,
+		queue:  make(chan []byte, cfg.MaxQueue),
+		done:   make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go s.loop()
+	return s
+}
+
+// Write implements io.Writer / zapcore.WriteSyncer. It copies p, since zap
+// reuses its encoding buffer after Write returns.
+func (s *asyncSink) Write(p []byte) (int, error) {
+	line := append([]byte(nil), p...)
+
+	if s.cfg.Blocking {
+		select {
+		case s.queue <- line:
+		case <-s.done:
+		}
+		return len(p), nil
+	}
+
+	select {
+	case s.queue <- line:
+	default:
+		atomic.AddUint64(&s.dropped, 1)
+	}
+	return len(p), nil
+}
+
+// Sync is a no-op: entries are already queued for async delivery.
+func (s *asyncSink) Sync() error { return nil }
+
+// Stats reports accepted/dropped/failed counters for monitoring.
+func (s *asyncSink) Stats() Stats {
+	return Stats{
+		Sent:    atomic.LoadUint64(&s.sent),
+		Dropped: atomic.LoadUint64(&s.dropped),
+		Failed:  atomic.LoadUint64(&s.failed),
+	}
+}
+
+// Close drains whatever is already queued through Sender and waits for the
+// background goroutine to exit. Lines written after Close are still queued
+// and reported as written, but are never sent.
+func (s *asyncSink) Close() error {
+	close(s.done)
+	s.wg.Wait()
+	return nil
+}
+
+func (s *asyncSink) loop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([][]byte, 0, s.cfg.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		s.send(batch)
+		batch = make([][]byte, 0, s.cfg.BatchSize)
+	}
+
+	for {
+		select {
+		case line := <-s.queue:
+			batch = append(batch, line)
+			if len(batch) >= s.cfg.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-s.done:
+			s.drain(&batch, flush)
+			return
+		}
+	}
+}
+
+func (s *asyncSink) drain(batch *[][]byte, flush func()) {
+	for {
+		select {
+		case line := <-s.queue:
+			*batch = append(*batch, line)
+			if len(*batch) >= s.cfg.BatchSize {
+				flush()
+			}
+		default:
+			flush()
+			return
+		}
+	}
+}
+
+func (s *asyncSink) send(batch [][]byte) {
+	backoff := s.cfg.InitialBackoff
+	for attempt := 0; attempt <= s.cfg.MaxRetries; attempt++ {
+		if err := s.sender.Send(batch); err == nil {
+			atomic.AddUint64(&s.sent, uint64(len(batch)))
+			return
+		}
+		if attempt == s.cfg.MaxRetries {
+			break
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > s.cfg.MaxBackoff {
+			backoff = s.cfg.MaxBackoff
+		}
+	}
+
+	atomic.AddUint64(&s.failed, uint64(len(batch)))
+	s.fallback(batch)
+}
+
+func (s *asyncSink) fallback(batch [][]byte) {
+	for _, line := range batch {
+		_, _ = os.Stderr.Write(line)
+	}
+}
@@ -0,0 +1,85 @@
+package sink
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSender struct {
+	mu      sync.Mutex
+	batches [][][]byte
+	failN   int32
+}
+
+func (f *fakeSender) Send(batch [][]byte) error {
+	if atomic.AddInt32(&f.failN, -1) >= 0 {
+		return errors.New("broker down")
+	}
+	f.mu.Lock()
+	f.batches = append(f.batches, batch)
+	f.mu.Unlock()
+	return nil
+}
+
+func (f *fakeSender) sent() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, b := range f.batches {
+		n += len(b)
+	}
+	return n
+}
+
+func TestAsyncSink(t *testing.T) {
+	convey.Convey("TestAsyncSink", t, func() {
+		convey.Convey("flushes on BatchSize and Close drains the rest", func() {
+			sender := &fakeSender{}
+			s := newAsyncSink(sender, Config{BatchSize: 2, FlushInterval: time.Hour, MaxQueue: 10})
+
+			_, _ = s.Write([]byte("a"))
+			_, _ = s.Write([]byte("b"))
+			_, _ = s.Write([]byte("c"))
+
+			assert.Nil(t, s.Close())
+			assert.Equal(t, 3, sender.sent())
+			assert.Equal(t, uint64(3), s.Stats().Sent)
+		})
+
+		convey.Convey("drops entries once MaxQueue is full without blocking", func() {
+			sender := &fakeSender{}
+			s := newAsyncSink(sender, Config{BatchSize: 1000, FlushInterval: time.Hour, MaxQueue: 1})
+
+			for i := 0; i < 5; i++ {
+				_, _ = s.Write([]byte("x"))
+			}
+
+			assert.Nil(t, s.Close())
+			assert.True(t, s.Stats().Dropped > 0)
+		})
+
+		convey.Convey("retries a failed batch then falls back to stderr after exhausting retries", func() {
+			sender := &fakeSender{failN: 100}
+			s := newAsyncSink(sender, Config{
+				BatchSize:      1,
+				FlushInterval:  time.Hour,
+				MaxQueue:       10,
+				MaxRetries:     2,
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     time.Millisecond,
+			})
+
+			_, _ = s.Write([]byte("x"))
+			assert.Nil(t, s.Close())
+
+			assert.Equal(t, uint64(1), s.Stats().Failed)
+			assert.Equal(t, 0, sender.sent())
+		})
+	})
+}
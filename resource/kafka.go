@@ -0,0 +1,26 @@
+//go:build kafka
+
+package resource
+
+import "github.com/why444216978/gin-api/library/logger/sink"
+
+// KafkaSinkConfig mirrors a YAML block such as `kafka: {brokers, topic,
+// acks, compression}`. Nothing loads it into ServiceLogger automatically:
+// build the sink with NewKafkaSink and pass it to InitLogger through
+// logger.WithSink. It only builds with -tags kafka, matching logger/sink's
+// KafkaSink.
+type KafkaSinkConfig struct {
+	Brokers     []string `yaml:"brokers"`
+	Topic       string   `yaml:"topic"`
+	Acks        string   `yaml:"acks"`
+	Compression string   `yaml:"compression"`
+}
+
+// NewKafkaSink builds a sink.KafkaSink from a KafkaSinkConfig, ready to pass
+// as the writer of a logger.WithSink option to InitLogger.
+func NewKafkaSink(cfg KafkaSinkConfig) (*sink.KafkaSink, error) {
+	return sink.NewKafkaSink(cfg.Brokers, cfg.Topic,
+		sink.WithKafkaAcks(cfg.Acks),
+		sink.WithKafkaCompression(cfg.Compression),
+	)
+}
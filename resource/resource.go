@@ -1,9 +1,11 @@
 package resource
 
 import (
+	stdhttp "net/http"
+
 	"github.com/why444216978/gin-api/library/config"
 	"github.com/why444216978/gin-api/library/etcd"
-	"github.com/why444216978/gin-api/library/logging"
+	"github.com/why444216978/gin-api/library/logger"
 	"github.com/why444216978/gin-api/library/orm"
 	"github.com/why444216978/gin-api/library/rpc/http"
 
@@ -13,8 +15,58 @@ import (
 var (
 	Config        *config.Viper
 	TestDB        *orm.Orm
-	ServiceLogger *logging.Logger
+	ServiceLogger *logger.Logger
+	Registry      *logger.LoggerRegistry
 	RedisCache    *redis.Client
 	Etcd          *etcd.Etcd
 	HTTPRPC       *http.RPC
+
+	// AdminMux serves internal operational endpoints, such as the dynamic
+	// log level handler, that must not be exposed on the public API mux.
+	AdminMux = stdhttp.NewServeMux()
 )
+
+// InitLogger builds ServiceLogger from cfg and opts and registers its dynamic
+// log-level and rate-limit metrics endpoints on AdminMux. Pass a
+// logger.WithSink option (e.g. wrapping a sink from resource.NewKafkaSink
+// under -tags kafka) to fan entries out to a broker.
+func InitLogger(cfg *logger.Config, opts ...logger.Option) error {
+	l, err := logger.NewLogger(cfg, opts...)
+	if err != nil {
+		return err
+	}
+
+	ServiceLogger = l
+	RegisterLevelHandler("service", l.LevelHandler())
+	RegisterMetricsHandler("service", l.MetricsHandler())
+
+	return nil
+}
+
+// InitLoggerRegistry builds Registry from ServiceLogger, seeded from
+// LOG_LEVELS, and registers its list/mutate endpoint on AdminMux so a
+// module's verbosity can be tuned independently of the rest. Call it after
+// InitLogger.
+func InitLoggerRegistry() {
+	Registry = logger.NewLoggerRegistry(ServiceLogger)
+	RegisterRegistryHandler(Registry.RegistryHandler())
+}
+
+// RegisterLevelHandler mounts a dynamic log-level endpoint for the named
+// logger on AdminMux: GET reports its current level, PUT changes it.
+func RegisterLevelHandler(name string, handler stdhttp.Handler) {
+	AdminMux.Handle("/debug/log/level/"+name, handler)
+}
+
+// RegisterMetricsHandler mounts the named logger's rate-limit accepted/dropped
+// counters on AdminMux as a JSON GET endpoint.
+func RegisterMetricsHandler(name string, handler stdhttp.Handler) {
+	AdminMux.Handle("/debug/log/metrics/"+name, handler)
+}
+
+// RegisterRegistryHandler mounts a LoggerRegistry's list/mutate endpoint on
+// AdminMux: GET lists every registered module's level, PUT
+// {"module":"db.*","level":"debug"} changes a glob's worth of them at once.
+func RegisterRegistryHandler(handler stdhttp.Handler) {
+	AdminMux.Handle("/debug/log/levels", handler)
+}